@@ -2,6 +2,7 @@ package bluzelle
 
 import (
 	"bytes"
+	"context"
 	"encoding/base64"
 	"encoding/hex"
 	"encoding/json"
@@ -14,8 +15,6 @@ import (
 	"time"
 
 	"github.com/btcsuite/btcd/btcec"
-	tmcrypto "github.com/tendermint/tendermint/crypto"
-	tmsecp256k1 "github.com/tendermint/tendermint/crypto/secp256k1"
 )
 
 const TX_COMMAND = "/txs"
@@ -24,6 +23,13 @@ const BROADCAST_MAX_RETRIES = 10
 const BROADCAST_RETRY_INTERVAL = time.Second
 const BLOCK_TIME_IN_SECONDS = 5
 
+// Cosmos REST broadcast modes. Block waits for the tx to be committed,
+// sync waits only for CheckTx, async returns immediately after the tx is
+// relayed to the mempool.
+const BROADCAST_MODE_BLOCK = "block"
+const BROADCAST_MODE_SYNC = "sync"
+const BROADCAST_MODE_ASYNC = "async"
+
 //
 // JSON struct keys are ordered alphabetically
 //
@@ -46,6 +52,9 @@ type GasInfo struct {
 	MaxGas   int `json:"max_gas"`
 	MaxFee   int `json:"max_fee"`
 	GasPrice int `json:"gas_price"`
+
+	// One of BROADCAST_MODE_BLOCK (default), _SYNC, or _ASYNC.
+	BroadcastMode string `json:"-"`
 }
 
 type LeaseInfo struct {
@@ -93,17 +102,10 @@ type TransactionSignature struct {
 //
 
 type Transaction struct {
-	Key       string
-	KeyValues []*KeyValue
-	Lease     int64
-	N         uint64
-	NewKey    string
-	Value     string
-
-	ApiRequestMethod   string
-	ApiRequestEndpoint string
-	GasInfo            *GasInfo
+	Msgs    []Msg
+	GasInfo *GasInfo
 
+	ctx              context.Context
 	done             chan bool
 	result           []byte
 	err              error
@@ -112,16 +114,12 @@ type Transaction struct {
 
 //
 
+// Per-type fields (Key, KeyValues, Lease, ...) are merged in from
+// Msg.MarshalValue() - see mergeValidateRequest.
 type TransactionValidateRequest struct {
-	BaseReq   *TransactionValidateRequestBaseReq `json:"BaseReq"`
-	Key       string                             `json:"Key,omitempty"`
-	KeyValues []*KeyValue                        `json:"KeyValues,omitempty"`
-	Lease     string                             `json:"Lease,omitempty"`
-	N         string                             `json:"N,omitempty"`
-	NewKey    string                             `json:"NewKey,omitempty"`
-	Owner     string                             `json:"Owner"`
-	UUID      string                             `json:"UUID"`
-	Value     string                             `json:"Value,omitempty"`
+	BaseReq *TransactionValidateRequestBaseReq `json:"BaseReq"`
+	Owner   string                             `json:"Owner"`
+	UUID    string                             `json:"UUID"`
 }
 
 type TransactionValidateRequestBaseReq struct {
@@ -153,20 +151,11 @@ type TransactionBroadcastResponse struct {
 
 //
 
-type TransactionMsgValue struct {
-	Key       string      `json:"Key,omitempty"`
-	KeyValues []*KeyValue `json:"KeyValues,omitempty"`
-	Lease     string      `json:"Lease,omitempty"`
-	N         string      `json:"N,omitempty"`
-	NewKey    string      `json:"NewKey,omitempty"`
-	Owner     string      `json:"Owner"`
-	UUID      string      `json:"UUID"`
-	Value     string      `json:"Value,omitempty"`
-}
-
+// TransactionMsg is the wire envelope for one Msg; Value is its raw
+// marshaled fields rather than a flat struct of known ones.
 type TransactionMsg struct {
-	Type  string               `json:"type"`
-	Value *TransactionMsgValue `json:"value"`
+	Type  string          `json:"type"`
+	Value json.RawMessage `json:"value"`
 }
 
 //
@@ -190,11 +179,20 @@ type TransactionBroadcastPayloadSignPayload struct {
 //
 
 func (ctx *Client) APIQuery(endpoint string) ([]byte, error) {
+	return ctx.APIQueryContext(context.Background(), endpoint)
+}
+
+func (ctx *Client) APIQueryContext(reqCtx context.Context, endpoint string) ([]byte, error) {
 	url := ctx.options.Endpoint + endpoint
 
 	ctx.Infof("get %s", url)
 
-	res, err := http.Get(url)
+	req, err := http.NewRequestWithContext(reqCtx, "GET", url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := http.DefaultClient.Do(req)
 	if err != nil {
 		return nil, err
 	}
@@ -206,15 +204,21 @@ func (ctx *Client) APIQuery(endpoint string) ([]byte, error) {
 }
 
 func (ctx *Client) APIMutate(method string, endpoint string, payload []byte) ([]byte, error) {
+	return ctx.APIMutateContext(context.Background(), method, endpoint, payload)
+}
+
+func (ctx *Client) APIMutateContext(reqCtx context.Context, method string, endpoint string, payload []byte) ([]byte, error) {
 	url := ctx.options.Endpoint + endpoint
 
 	ctx.Infof("post %s", url)
 
-	req, err := http.NewRequest(method, url, bytes.NewBuffer(payload))
+	req, err := http.NewRequestWithContext(reqCtx, method, url, bytes.NewBuffer(payload))
+	if err != nil {
+		return nil, err
+	}
 	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
 
-	client := &http.Client{}
-	res, err := client.Do(req)
+	res, err := http.DefaultClient.Do(req)
 	if err != nil {
 		return nil, err
 	}
@@ -226,12 +230,23 @@ func (ctx *Client) APIMutate(method string, endpoint string, payload []byte) ([]
 }
 
 func (ctx *Client) SendTransaction(txn *Transaction) ([]byte, error) {
+	return ctx.SendTransactionContext(context.Background(), txn)
+}
+
+func (ctx *Client) SendTransactionContext(reqCtx context.Context, txn *Transaction) ([]byte, error) {
+	txn.ctx = reqCtx
 	txn.done = make(chan bool, 1)
 	ctx.transactions <- txn
-	done := <-txn.done
-	if !done {
-		ctx.Fatalf("txn did not complete") // todo: enqueue
+
+	select {
+	case done := <-txn.done:
+		if !done {
+			ctx.Fatalf("txn did not complete") // todo: enqueue
+		}
+	case <-reqCtx.Done():
+		return nil, reqCtx.Err()
 	}
+
 	if txn.err != nil {
 		ctx.Errorf("transaction err(%s)", txn.err)
 	}
@@ -241,10 +256,19 @@ func (ctx *Client) SendTransaction(txn *Transaction) ([]byte, error) {
 func (ctx *Client) ProcessTransaction(txn *Transaction) {
 	txn.broadcastRetries = 0
 
+	txnCtx := txn.ctx
+	if txnCtx == nil {
+		txnCtx = context.Background()
+	}
+
 	var result []byte
-	payload, err := ctx.ValidateTransaction(txn)
-	if err == nil {
-		result, err = ctx.BroadcastTransaction(payload, txn.GasInfo)
+	var err error
+	if err = txnCtx.Err(); err == nil {
+		var payload *TransactionBroadcastPayload
+		payload, err = ctx.ValidateTransaction(txnCtx, txn)
+		if err == nil {
+			result, err = ctx.BroadcastTransaction(txnCtx, payload, txn.GasInfo)
+		}
 	}
 
 	txn.result = result
@@ -254,28 +278,25 @@ func (ctx *Client) ProcessTransaction(txn *Transaction) {
 }
 
 // Get required min gas
-func (ctx *Client) ValidateTransaction(txn *Transaction) (*TransactionBroadcastPayload, error) {
+func (ctx *Client) ValidateTransaction(reqCtx context.Context, txn *Transaction) (*TransactionBroadcastPayload, error) {
 	req := &TransactionValidateRequest{
 		BaseReq: &TransactionValidateRequestBaseReq{
 			From:    ctx.Address,
 			ChainId: ctx.options.ChainId,
 		},
-		UUID:      ctx.options.UUID,
-		Key:       txn.Key,
-		KeyValues: txn.KeyValues,
-		Lease:     strconv.FormatInt(txn.Lease, 10),
-		N:         strconv.FormatUint(txn.N, 10),
-		NewKey:    txn.NewKey,
-		Owner:     ctx.Address,
-		Value:     txn.Value,
+		UUID:  ctx.options.UUID,
+		Owner: ctx.Address,
 	}
 
-	reqBytes, err := json.Marshal(req)
+	reqBytes, err := mergeValidateRequest(req, txn.Msgs)
 	if err != nil {
 		return nil, err
 	}
 	ctx.Infof("txn init %+v", string(reqBytes))
-	body, err := ctx.APIMutate(txn.ApiRequestMethod, txn.ApiRequestEndpoint, reqBytes)
+	// All msgs in a Transaction currently share one validate call, so the
+	// first msg's endpoint decides where the request goes.
+	method, endpoint := txn.Msgs[0].ValidateEndpoint()
+	body, err := ctx.APIMutateContext(reqCtx, method, endpoint, reqBytes)
 	if err != nil {
 		return nil, err
 	}
@@ -288,10 +309,43 @@ func (ctx *Client) ValidateTransaction(txn *Transaction) (*TransactionBroadcastP
 		return nil, err
 	}
 
-	return res.Value, nil
+	// Build the broadcast Msg array from txn.Msgs directly rather than
+	// trusting the validate response's copy.
+	payload := res.Value
+	payload.Msg, err = buildTransactionMsgs(txn.Msgs)
+	if err != nil {
+		return nil, err
+	}
+
+	return payload, nil
 }
 
-func (ctx *Client) BroadcastTransaction(txn *TransactionBroadcastPayload, gasInfo *GasInfo) ([]byte, error) {
+// BroadcastTransaction retries on a stale sequence number by refetching the
+// account and resubmitting. TxPool calls broadcastTransactionOnce directly
+// instead, since it handles a stale sequence itself.
+func (ctx *Client) BroadcastTransaction(reqCtx context.Context, txn *TransactionBroadcastPayload, gasInfo *GasInfo) ([]byte, error) {
+	body, err := ctx.broadcastTransactionOnce(reqCtx, txn, gasInfo)
+	if err == nil {
+		return body, nil
+	}
+	if !strings.Contains(err.Error(), "signature verification failed") {
+		return nil, err
+	}
+
+	ctx.broadcastRetries += 1
+	ctx.Warnf("txn failed ... retrying(%d) ...", ctx.broadcastRetries)
+	if ctx.broadcastRetries >= BROADCAST_MAX_RETRIES {
+		return nil, fmt.Errorf("txn failed after max retry attempts")
+	}
+	time.Sleep(BROADCAST_RETRY_INTERVAL)
+	// Lookup changed sequence
+	if err := ctx.setAccount(); err != nil {
+		return nil, err
+	}
+	return ctx.BroadcastTransaction(reqCtx, txn, gasInfo)
+}
+
+func (ctx *Client) broadcastTransactionOnce(reqCtx context.Context, txn *TransactionBroadcastPayload, gasInfo *GasInfo) ([]byte, error) {
 	// Set memo
 	txn.Memo = makeRandomString(32)
 
@@ -326,14 +380,14 @@ func (ctx *Client) BroadcastTransaction(txn *TransactionBroadcastPayload, gasInf
 	}
 
 	// Set signatures
-	if signature, err := ctx.SignTransaction(txn); err != nil {
+	if signature, pubKey, err := ctx.SignTransaction(reqCtx, txn); err != nil {
 		return nil, err
 	} else {
 		txn.Signatures = []*TransactionSignature{
 			&TransactionSignature{
 				PubKey: &TransactionSignaturePubKey{
-					Type:  tmsecp256k1.PubKeyAminoName,
-					Value: base64.StdEncoding.EncodeToString(ctx.privateKey.PubKey().SerializeCompressed()),
+					Type:  ctx.signer.PubKeyType(),
+					Value: base64.StdEncoding.EncodeToString(pubKey),
 				},
 				Signature:     signature,
 				AccountNumber: strconv.Itoa(ctx.account.AccountNumber),
@@ -343,16 +397,20 @@ func (ctx *Client) BroadcastTransaction(txn *TransactionBroadcastPayload, gasInf
 	}
 
 	// Broadcast txn
+	mode := BROADCAST_MODE_BLOCK
+	if gasInfo.BroadcastMode != "" {
+		mode = gasInfo.BroadcastMode
+	}
 	req := &TransactionBroadcastRequest{
 		Transaction: txn,
-		Mode:        "block",
+		Mode:        mode,
 	}
 	reqBytes, err := json.Marshal(req)
 	if err != nil {
 		return nil, err
 	}
 	ctx.Infof("txn broadcast request %+v", string(reqBytes))
-	body, err := ctx.APIMutate("POST", TX_COMMAND, reqBytes)
+	body, err := ctx.APIMutateContext(reqCtx, "POST", TX_COMMAND, reqBytes)
 	if err != nil {
 		return nil, err
 	}
@@ -373,6 +431,18 @@ func (ctx *Client) BroadcastTransaction(txn *TransactionBroadcastPayload, gasInf
 	//
 	// this is far from ideal, doesn't match their docs, and is probably going to change (again) in the future.
 
+	// sync/async modes return before the tx lands in a block, so hand back
+	// a TxHandle instead of decoded result bytes.
+	if mode != BROADCAST_MODE_BLOCK {
+		if res.Code != 0 {
+			return nil, fmt.Errorf("%s", res.RawLog)
+		}
+		// Bump optimistically so back-to-back async broadcasts don't sign
+		// with the same sequence; WaitForCommit/setAccount catch a bad guess.
+		ctx.account.Sequence += 1
+		return json.Marshal(&TxHandle{Hash: res.TxHash})
+	}
+
 	if res.Code == 0 {
 		ctx.account.Sequence += 1
 		if res.Data == "" {
@@ -381,25 +451,11 @@ func (ctx *Client) BroadcastTransaction(txn *TransactionBroadcastPayload, gasInf
 		decodedData, err := hex.DecodeString(res.Data)
 		return decodedData, err
 	}
-	if strings.Contains(res.RawLog, "signature verification failed") {
-		ctx.broadcastRetries += 1
-		ctx.Warnf("txn failed ... retrying(%d) ...", ctx.broadcastRetries)
-		if ctx.broadcastRetries >= BROADCAST_MAX_RETRIES {
-			return nil, fmt.Errorf("txn failed after max retry attempts")
-		}
-		time.Sleep(BROADCAST_RETRY_INTERVAL)
-		// Lookup changed sequence
-		if err := ctx.setAccount(); err != nil {
-			return nil, err
-		}
-		b, err := ctx.BroadcastTransaction(txn, gasInfo)
-		return b, err
-	}
 
 	return nil, fmt.Errorf("%s", res.RawLog)
 }
 
-func (ctx *Client) SignTransaction(txn *TransactionBroadcastPayload) (string, error) {
+func (ctx *Client) SignTransaction(reqCtx context.Context, txn *TransactionBroadcastPayload) (string, []byte, error) {
 	payload := &TransactionBroadcastPayloadSignPayload{
 		AccountNumber: strconv.Itoa(ctx.account.AccountNumber),
 		ChainId:       ctx.options.ChainId,
@@ -410,16 +466,15 @@ func (ctx *Client) SignTransaction(txn *TransactionBroadcastPayload) (string, er
 	}
 	payloadBytes, err := json.Marshal(payload)
 	if err != nil {
-		return "", err
+		return "", nil, err
 	}
 	sanitized := sanitizeString(string(payloadBytes))
 	ctx.Infof("txn sign %+v", sanitized)
-	hash := tmcrypto.Sha256([]byte(sanitized))
-	if s, err := ctx.privateKey.Sign(hash); err != nil {
-		return "", err
-	} else {
-		return base64.StdEncoding.EncodeToString(serializeSig(s)), nil
+	sig, pubKey, err := ctx.signer.Sign(reqCtx, []byte(sanitized))
+	if err != nil {
+		return "", nil, err
 	}
+	return base64.StdEncoding.EncodeToString(sig), pubKey, nil
 }
 
 func makeRandomString(length int) string {
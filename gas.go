@@ -0,0 +1,83 @@
+package bluzelle
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+)
+
+const DEFAULT_GAS_ADJUSTMENT = 1.5
+const DEFAULT_GAS_PRICE = 10
+
+const MIN_GAS_PRICE_ENDPOINT = "/node_fees/min_gas_price"
+
+type minGasPriceResponse struct {
+	MinGasPrice int `json:"min_gas_price"`
+}
+
+// EstimateGas validates txn to get a suggested Fee.Gas, applies
+// options.GasAdjustment, and fills GasPrice from the node.
+func (ctx *Client) EstimateGas(txn *Transaction) (*GasInfo, error) {
+	return ctx.EstimateGasContext(context.Background(), txn)
+}
+
+func (ctx *Client) EstimateGasContext(reqCtx context.Context, txn *Transaction) (*GasInfo, error) {
+	payload, err := ctx.ValidateTransaction(reqCtx, txn)
+	if err != nil {
+		return nil, err
+	}
+
+	gas, err := strconv.Atoi(payload.Fee.Gas)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse suggested gas(%s)", payload.Fee.Gas)
+	}
+
+	adjustment := ctx.options.GasAdjustment
+	if adjustment == 0 {
+		adjustment = DEFAULT_GAS_ADJUSTMENT
+	}
+
+	return &GasInfo{
+		MaxGas:   int(float64(gas) * adjustment),
+		GasPrice: ctx.minGasPrice(reqCtx),
+	}, nil
+}
+
+// FillTransaction populates txn.GasInfo via EstimateGas when it is nil.
+func (ctx *Client) FillTransaction(txn *Transaction) error {
+	return ctx.FillTransactionContext(context.Background(), txn)
+}
+
+func (ctx *Client) FillTransactionContext(reqCtx context.Context, txn *Transaction) error {
+	if txn.GasInfo != nil {
+		return nil
+	}
+	gasInfo, err := ctx.EstimateGasContext(reqCtx, txn)
+	if err != nil {
+		return err
+	}
+	txn.GasInfo = gasInfo
+	return nil
+}
+
+// minGasPrice falls back to options.DefaultGasPrice (or DEFAULT_GAS_PRICE)
+// if the node doesn't expose one.
+func (ctx *Client) minGasPrice(reqCtx context.Context) int {
+	fallback := ctx.options.DefaultGasPrice
+	if fallback == 0 {
+		fallback = DEFAULT_GAS_PRICE
+	}
+
+	body, err := ctx.APIQueryContext(reqCtx, MIN_GAS_PRICE_ENDPOINT)
+	if err != nil {
+		return fallback
+	}
+
+	res := &minGasPriceResponse{}
+	if err := json.Unmarshal(body, res); err != nil || res.MinGasPrice == 0 {
+		return fallback
+	}
+
+	return res.MinGasPrice
+}
@@ -0,0 +1,94 @@
+package bluzelle
+
+import (
+	"encoding/json"
+	"fmt"
+	"sync"
+)
+
+// Msg is a single CRUD operation carried inside a Transaction envelope, so
+// new operations can be registered without forking the library.
+type Msg interface {
+	// Type is the Cosmos SDK message type, e.g. "crud/Create".
+	Type() string
+
+	// MarshalValue returns this message's JSON-serializable fields, merged
+	// alongside BaseReq/Owner/UUID when posted to ValidateEndpoint.
+	MarshalValue() interface{}
+
+	// ValidateEndpoint is the method/path used to pre-validate this
+	// message, e.g. ("POST", "/crud/create").
+	ValidateEndpoint() (method string, path string)
+}
+
+var msgRegistry = map[string]func() Msg{}
+var msgRegistryMu sync.Mutex
+
+// RegisterMsg lets downstream code add new Cosmos message types without
+// forking the library.
+func RegisterMsg(msgType string, factory func() Msg) {
+	msgRegistryMu.Lock()
+	defer msgRegistryMu.Unlock()
+	msgRegistry[msgType] = factory
+}
+
+func newMsg(msgType string) (Msg, error) {
+	msgRegistryMu.Lock()
+	factory, ok := msgRegistry[msgType]
+	msgRegistryMu.Unlock()
+	if !ok {
+		return nil, fmt.Errorf("no Msg registered for type(%s)", msgType)
+	}
+	return factory(), nil
+}
+
+// mergeValidateRequest flattens req and every msg's MarshalValue() into a
+// single JSON object, as the ValidateEndpoint() paths (e.g. /crud/keyvalues)
+// expect.
+func mergeValidateRequest(req *TransactionValidateRequest, msgs []Msg) ([]byte, error) {
+	reqBytes, err := json.Marshal(req)
+	if err != nil {
+		return nil, err
+	}
+
+	merged := map[string]json.RawMessage{}
+	if err := json.Unmarshal(reqBytes, &merged); err != nil {
+		return nil, err
+	}
+
+	for _, msg := range msgs {
+		valueBytes, err := json.Marshal(msg.MarshalValue())
+		if err != nil {
+			return nil, err
+		}
+		fields := map[string]json.RawMessage{}
+		if err := json.Unmarshal(valueBytes, &fields); err != nil {
+			return nil, err
+		}
+		for k, v := range fields {
+			merged[k] = v
+		}
+	}
+
+	return json.Marshal(merged)
+}
+
+// buildTransactionMsgs turns msgs into the wire envelope posted to /txs.
+// Every msg must be registered via RegisterMsg, so a typo'd or unregistered
+// Type() fails before the tx is ever signed.
+func buildTransactionMsgs(msgs []Msg) ([]*TransactionMsg, error) {
+	built := make([]*TransactionMsg, 0, len(msgs))
+	for _, msg := range msgs {
+		if _, err := newMsg(msg.Type()); err != nil {
+			return nil, err
+		}
+
+		valueBytes, err := json.Marshal(msg.MarshalValue())
+		if err != nil {
+			return nil, err
+		}
+
+		built = append(built, &TransactionMsg{Type: msg.Type(), Value: valueBytes})
+	}
+	return built, nil
+}
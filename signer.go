@@ -0,0 +1,115 @@
+package bluzelle
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/btcsuite/btcd/btcec"
+	tmcrypto "github.com/tendermint/tendermint/crypto"
+	tmsecp256k1 "github.com/tendermint/tendermint/crypto/secp256k1"
+)
+
+// Signer abstracts signing a transaction payload, so BroadcastTransaction
+// doesn't need to know whether the signature came from an in-memory key or
+// a remote HSM/KMS/Ledger.
+type Signer interface {
+	Sign(reqCtx context.Context, payload []byte) (sig []byte, pub []byte, err error)
+
+	// PubKeyType is the amino type name, e.g. tmsecp256k1.PubKeyAminoName.
+	PubKeyType() string
+}
+
+//
+
+// MnemonicSigner signs with an in-memory secp256k1 private key.
+type MnemonicSigner struct {
+	privateKey *btcec.PrivateKey
+}
+
+func NewMnemonicSigner(privateKey *btcec.PrivateKey) *MnemonicSigner {
+	return &MnemonicSigner{privateKey: privateKey}
+}
+
+func (signer *MnemonicSigner) Sign(reqCtx context.Context, payload []byte) ([]byte, []byte, error) {
+	hash := tmcrypto.Sha256(payload)
+	sig, err := signer.privateKey.Sign(hash)
+	if err != nil {
+		return nil, nil, err
+	}
+	return serializeSig(sig), signer.privateKey.PubKey().SerializeCompressed(), nil
+}
+
+func (signer *MnemonicSigner) PubKeyType() string {
+	return tmsecp256k1.PubKeyAminoName
+}
+
+//
+
+type remoteSignerRequest struct {
+	Payload string `json:"payload"`
+}
+
+type remoteSignerResponse struct {
+	Signature string `json:"signature"`
+	Error     string `json:"error"`
+}
+
+// RemoteSigner delegates signing to an external signing service over HTTP,
+// e.g. a cosmos-sdk signer daemon, Tendermint KMS, or a Ledger/HSM bridge.
+type RemoteSigner struct {
+	Endpoint string
+	PubKey   []byte
+	KeyType  string
+}
+
+func NewRemoteSigner(endpoint string, pubKey []byte, keyType string) *RemoteSigner {
+	return &RemoteSigner{Endpoint: endpoint, PubKey: pubKey, KeyType: keyType}
+}
+
+func (signer *RemoteSigner) Sign(reqCtx context.Context, payload []byte) ([]byte, []byte, error) {
+	reqBytes, err := json.Marshal(&remoteSignerRequest{
+		Payload: base64.StdEncoding.EncodeToString(payload),
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	req, err := http.NewRequestWithContext(reqCtx, "POST", signer.Endpoint, bytes.NewBuffer(reqBytes))
+	if err != nil {
+		return nil, nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	res, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer res.Body.Close()
+
+	body, err := parseResponse(res)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	sigRes := &remoteSignerResponse{}
+	if err := json.Unmarshal(body, sigRes); err != nil {
+		return nil, nil, err
+	}
+	if sigRes.Error != "" {
+		return nil, nil, fmt.Errorf("%s", sigRes.Error)
+	}
+
+	sig, err := base64.StdEncoding.DecodeString(sigRes.Signature)
+	if err != nil {
+		return nil, nil, err
+	}
+	return sig, signer.PubKey, nil
+}
+
+func (signer *RemoteSigner) PubKeyType() string {
+	return signer.KeyType
+}
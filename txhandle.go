@@ -0,0 +1,51 @@
+package bluzelle
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// TxHandle is returned by BroadcastTransaction for sync/async broadcast
+// modes, where the tx hash is known before the tx has actually landed in a
+// block.
+type TxHandle struct {
+	Hash string `json:"txhash"`
+}
+
+// ParseTxHandle decodes the result bytes returned by SendTransaction for a
+// sync/async broadcast into a TxHandle.
+func ParseTxHandle(result []byte) (*TxHandle, error) {
+	handle := &TxHandle{}
+	if err := json.Unmarshal(result, handle); err != nil {
+		return nil, err
+	}
+	return handle, nil
+}
+
+// WaitForCommit polls /txs/{hash} until the transaction is included in a
+// block, reqCtx is cancelled, or timeout elapses.
+func (ctx *Client) WaitForCommit(reqCtx context.Context, hash string, timeout time.Duration) (*TransactionBroadcastResponse, error) {
+	deadline := time.Now().Add(timeout)
+
+	for {
+		body, err := ctx.APIQueryContext(reqCtx, TX_COMMAND+"/"+hash)
+		if err == nil {
+			res := &TransactionBroadcastResponse{}
+			if err := json.Unmarshal(body, res); err == nil && res.Height != "" && res.Height != "0" {
+				return res, nil
+			}
+		}
+
+		if time.Now().After(deadline) {
+			return nil, fmt.Errorf("timed out waiting for txn(%s) to be committed", hash)
+		}
+
+		select {
+		case <-reqCtx.Done():
+			return nil, reqCtx.Err()
+		case <-time.After(BROADCAST_RETRY_INTERVAL):
+		}
+	}
+}
@@ -1,18 +1,47 @@
 package bluzelle
 
 import (
+	"context"
 	"encoding/json"
 )
 
+const MsgTypeKeyValues = "crud/KeyValues"
+
+func init() {
+	RegisterMsg(MsgTypeKeyValues, func() Msg { return &KeyValuesMsg{} })
+}
+
+// KeyValuesMsg requests every key/value pair owned by the signer's
+// UUID/Owner. It carries no fields of its own beyond BaseReq/Owner/UUID.
+type KeyValuesMsg struct{}
+
+func (msg *KeyValuesMsg) Type() string {
+	return MsgTypeKeyValues
+}
+
+func (msg *KeyValuesMsg) MarshalValue() interface{} {
+	return struct{}{}
+}
+
+func (msg *KeyValuesMsg) ValidateEndpoint() (string, string) {
+	return "POST", "/crud/keyvalues"
+}
+
 func (ctx *Client) TxKeyValues(gasInfo *GasInfo) ([]*KeyValuesResponseResultKeyValue, error) {
+	return ctx.TxKeyValuesContext(context.Background(), gasInfo)
+}
+
+func (ctx *Client) TxKeyValuesContext(reqCtx context.Context, gasInfo *GasInfo) ([]*KeyValuesResponseResultKeyValue, error) {
 	transaction := &Transaction{
-		ApiRequestMethod:   "POST",
-		ApiRequestEndpoint: "/crud/keyvalues",
-		GasInfo:            gasInfo,
-		Client:             ctx,
+		Msgs:    []Msg{&KeyValuesMsg{}},
+		GasInfo: gasInfo,
+	}
+
+	if err := ctx.FillTransactionContext(reqCtx, transaction); err != nil {
+		return nil, err
 	}
 
-	body, err := ctx.SendTransaction(transaction)
+	body, err := ctx.SendTransactionContext(reqCtx, transaction)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,174 @@
+package bluzelle
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// TxPool batches concurrently submitted transactions into as few broadcasts
+// as possible, predicting sequence numbers locally instead of serializing
+// everything behind Client.transactions.
+type TxPool struct {
+	client *Client
+
+	mu      sync.Mutex
+	pending []*pooledTx
+	nextSeq int
+}
+
+type pooledTx struct {
+	ctx context.Context
+	txn *Transaction
+}
+
+func NewTxPool(client *Client) *TxPool {
+	return &TxPool{client: client, nextSeq: client.account.Sequence}
+}
+
+// Add enqueues txn for the next Flush and allocates the done channel
+// SendTransaction-style callers block on. The sequence number isn't known
+// until Flush decides how txs group.
+func (pool *TxPool) Add(reqCtx context.Context, txn *Transaction) {
+	pool.mu.Lock()
+	defer pool.mu.Unlock()
+
+	txn.ctx = reqCtx
+	txn.done = make(chan bool, 1)
+	pool.pending = append(pool.pending, &pooledTx{ctx: reqCtx, txn: txn})
+}
+
+// Flush batches pending transactions that stay under GasInfo.MaxGas into
+// single broadcasts and resolves each Transaction.done/result/err in place.
+func (pool *TxPool) Flush() error {
+	pool.mu.Lock()
+	batch := pool.pending
+	pool.pending = nil
+	pool.mu.Unlock()
+
+	for len(batch) > 0 {
+		group := pool.nextGroup(batch)
+
+		// A group consumes exactly one on-chain sequence number regardless
+		// of how many txs it batches.
+		seq := pool.nextSeq
+		pool.nextSeq++
+
+		result, err := pool.broadcastGroup(group, seq)
+		if err != nil && strings.Contains(err.Error(), "signature verification failed") {
+			// Stale sequence - refetch the account and resubmit the
+			// remaining pipeline; group's done channels are still unresolved.
+			if err := pool.client.setAccount(); err != nil {
+				pool.failAll(batch, err)
+				return err
+			}
+			pool.nextSeq = pool.client.account.Sequence
+			continue
+		}
+
+		pool.resolve(group, result, err)
+		if err != nil {
+			pool.failAll(batch[len(group):], err)
+			return err
+		}
+
+		batch = batch[len(group):]
+	}
+
+	return nil
+}
+
+// nextGroup returns the largest run at the head of batch whose combined gas
+// estimate stays under the first tx's GasInfo.MaxGas.
+func (pool *TxPool) nextGroup(batch []*pooledTx) []*pooledTx {
+	maxGas := 0
+	if first := batch[0].txn.GasInfo; first != nil {
+		maxGas = first.MaxGas
+	}
+
+	gas := 0
+	i := 0
+	for i < len(batch) {
+		gas += estimatedGas(batch[i].txn)
+		if maxGas != 0 && gas > maxGas {
+			if i == 0 {
+				i = 1 // always send at least one txn, even if it alone exceeds the budget
+			}
+			break
+		}
+		i++
+	}
+
+	return batch[:i]
+}
+
+// broadcastGroup merges the group's validated msgs and fees into a single
+// TransactionBroadcastPayload and broadcasts it once against seq. It uses
+// broadcastTransactionOnce rather than BroadcastTransaction and leaves
+// pooled.txn.done unresolved, since Flush may still retry the whole group
+// under a refreshed sequence.
+func (pool *TxPool) broadcastGroup(group []*pooledTx, seq int) ([]byte, error) {
+	client := pool.client
+
+	payload := &TransactionBroadcastPayload{}
+	var gasInfo *GasInfo
+	totalGas, totalAmount := 0, 0
+	denom := TOKEN_NAME
+
+	for _, pooled := range group {
+		validated, err := client.ValidateTransaction(pooled.ctx, pooled.txn)
+		if err != nil {
+			return nil, err
+		}
+		payload.Msg = append(payload.Msg, validated.Msg...)
+		if validated.Fee != nil {
+			if gas, err := strconv.Atoi(validated.Fee.Gas); err == nil {
+				totalGas += gas
+			}
+			for _, amount := range validated.Fee.Amount {
+				denom = amount.Denom
+				if a, err := strconv.Atoi(amount.Amount); err == nil {
+					totalAmount += a
+				}
+			}
+		}
+		if pooled.txn.GasInfo != nil {
+			gasInfo = pooled.txn.GasInfo
+		}
+	}
+	payload.Fee = &TransactionFee{
+		Gas:    strconv.Itoa(totalGas),
+		Amount: []*TransactionFeeAmount{&TransactionFeeAmount{Denom: denom, Amount: strconv.Itoa(totalAmount)}},
+	}
+
+	client.account.Sequence = seq
+
+	return client.broadcastTransactionOnce(group[0].ctx, payload, gasInfo)
+}
+
+func (pool *TxPool) resolve(group []*pooledTx, result []byte, err error) {
+	for _, pooled := range group {
+		pooled.txn.result = result
+		pooled.txn.err = err
+		pooled.txn.done <- true
+		close(pooled.txn.done)
+	}
+}
+
+func (pool *TxPool) failAll(batch []*pooledTx, err error) {
+	for _, pooled := range batch {
+		pooled.txn.err = err
+		pooled.txn.done <- true
+		close(pooled.txn.done)
+	}
+}
+
+// estimatedGas is a crude pre-validate guess so the pool can decide whether
+// a tx still fits the current batch before paying for a validate round trip.
+func estimatedGas(txn *Transaction) int {
+	if txn.GasInfo != nil && txn.GasInfo.MaxGas != 0 {
+		return txn.GasInfo.MaxGas / 10
+	}
+	return 0
+}
@@ -0,0 +1,60 @@
+package bluzelle
+
+import (
+	"context"
+	"testing"
+)
+
+func TestTxPoolAddInitializesDoneChannel(t *testing.T) {
+	pool := &TxPool{}
+	txn := &Transaction{}
+
+	pool.Add(context.Background(), txn)
+
+	if txn.done == nil {
+		t.Fatalf("expected Add to initialize txn.done")
+	}
+}
+
+func TestTxPoolNextGroupBatchesMultipleTxs(t *testing.T) {
+	pool := &TxPool{}
+	gasInfo := &GasInfo{MaxGas: 10}
+
+	batch := make([]*pooledTx, 12)
+	for i := range batch {
+		batch[i] = &pooledTx{txn: &Transaction{GasInfo: gasInfo}}
+	}
+
+	group := pool.nextGroup(batch)
+
+	if len(group) != 10 {
+		t.Fatalf("expected a batch of 10 txs under the gas budget, got %d", len(group))
+	}
+}
+
+// Flush itself needs a live node, so this checks nextGroup's grouping and
+// repeats Flush's per-group sequence step by hand.
+func TestTxPoolNextGroupThenSequenceStepOncePerGroup(t *testing.T) {
+	pool := &TxPool{nextSeq: 7}
+	gasInfo := &GasInfo{MaxGas: 25}
+
+	batch := []*pooledTx{
+		{txn: &Transaction{GasInfo: gasInfo}},
+		{txn: &Transaction{GasInfo: gasInfo}},
+	}
+
+	group := pool.nextGroup(batch)
+	if len(group) != 2 {
+		t.Fatalf("expected both txs to batch into a single group, got %d", len(group))
+	}
+
+	seq := pool.nextSeq
+	pool.nextSeq++
+
+	if seq != 7 {
+		t.Fatalf("expected the group to consume the pool's current sequence(7), got %d", seq)
+	}
+	if pool.nextSeq != 8 {
+		t.Fatalf("expected nextSeq to advance by one per group regardless of group size, got %d", pool.nextSeq)
+	}
+}